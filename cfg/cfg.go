@@ -0,0 +1,100 @@
+// Package cfg defines the on-disk configuration schema Inertia persists
+// to a project's inertia.toml: the project's build settings and the set
+// of remotes it can be deployed to.
+package cfg
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config is the root of a project's inertia.toml
+type Config struct {
+	Version       string                `toml:"version"`
+	Project       string                `toml:"project-name"`
+	BuildType     string                `toml:"build-type"`
+	BuildFilePath string                `toml:"build-file-path"`
+	Remotes       map[string]*RemoteVPS `toml:"remotes"`
+}
+
+// RemoteVPS holds everything needed to reach and deploy to a single
+// remote VPS instance
+type RemoteVPS struct {
+	Name    string `toml:"name"`
+	IP      string `toml:"ip"`
+	User    string `toml:"user"`
+	PEM     string `toml:"pem"`
+	Branch  string `toml:"branch"`
+	SSHPort string `toml:"ssh-port"`
+
+	// Provider selects the remote.Remote driver used to activate and
+	// verify webhooks for this remote - see the remote package. Empty
+	// is treated as remote.GitHub for backwards compatibility.
+	Provider string `toml:"provider"`
+
+	Daemon *DaemonConfig `toml:"daemon"`
+}
+
+// DaemonConfig holds the settings needed to reach the Inertia daemon
+// running on a RemoteVPS
+type DaemonConfig struct {
+	Port   string `toml:"port"`
+	Secret string `toml:"secret"`
+	Token  string `toml:"token"`
+
+	// TrustedCert is the PEM-encoded daemon certificate pinned on
+	// "inertia remote add" (or "inertia remote trust"). When set, it is
+	// the only certificate buildHTTPSClient will accept for this remote.
+	TrustedCert []byte `toml:"trusted-cert"`
+
+	// RequestTimeout bounds how long a single client request may take
+	// before it is cancelled. Zero means the client's default applies.
+	RequestTimeout time.Duration `toml:"request-timeout"`
+}
+
+// Load reads and parses a project's inertia.toml from path
+func Load(path string) (*Config, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := toml.Unmarshal(raw, &config); err != nil {
+		return nil, err
+	}
+	if config.Remotes == nil {
+		config.Remotes = make(map[string]*RemoteVPS)
+	}
+	return &config, nil
+}
+
+// Write serializes config back to path as TOML
+func (config *Config) Write(path string) error {
+	raw, err := toml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, raw, 0644)
+}
+
+// AddRemote registers (or replaces) a remote on this config
+func (config *Config) AddRemote(remote *RemoteVPS) {
+	if config.Remotes == nil {
+		config.Remotes = make(map[string]*RemoteVPS)
+	}
+	config.Remotes[remote.Name] = remote
+}
+
+// GetRemote looks up a remote by name
+func (config *Config) GetRemote(name string) (*RemoteVPS, bool) {
+	remote, found := config.Remotes[name]
+	return remote, found
+}
+
+// GetIPAndPort returns the "ip:port" address of this remote's daemon
+func (remote *RemoteVPS) GetIPAndPort() string {
+	return remote.IP + ":" + remote.Daemon.Port
+}
@@ -0,0 +1,16 @@
+package client
+
+import "github.com/ubclaunchpad/inertia/cfg"
+
+// RemoteVPS is the remote configuration a Deployment operates against
+type RemoteVPS = cfg.RemoteVPS
+
+// defaultConfigFilePath is the inertia.toml Inertia expects to find in
+// the current project directory
+const defaultConfigFilePath = "inertia.toml"
+
+// GetProjectConfigFromDisk reads the project's inertia.toml from the
+// current working directory
+func GetProjectConfigFromDisk() (*cfg.Config, error) {
+	return cfg.Load(defaultConfigFilePath)
+}
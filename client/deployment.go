@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -12,11 +13,20 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ubclaunchpad/inertia/common"
+	"golang.org/x/crypto/bcrypt"
 	git "gopkg.in/src-d/go-git.v4"
 )
 
+// defaultRequestTimeout caps a request when the remote has not configured
+// Daemon.RequestTimeout
+const defaultRequestTimeout = 30 * time.Second
+
+// bcryptCost is the work factor used when hashing user passwords client-side
+const bcryptCost = 12
+
 // Deployment manages a deployment
 type Deployment struct {
 	*RemoteVPS
@@ -24,6 +34,11 @@ type Deployment struct {
 	Auth       string
 	Project    string
 	BuildType  string
+
+	// Insecure disables certificate verification entirely. It is only
+	// ever set from the CLI's --insecure flag, for remotes that have
+	// not been TOFU-pinned.
+	Insecure bool
 }
 
 // GetDeployment returns the local deployment setup
@@ -55,8 +70,10 @@ func GetDeployment(name string) (*Deployment, error) {
 
 // Up brings the project up on the remote VPS instance specified
 // in the deployment object.
-func (d *Deployment) Up(buildType string, stream bool) (*http.Response, error) {
-	// TODO: Support other Git remotes.
+func (d *Deployment) Up(ctx context.Context, buildType string, stream bool) (*http.Response, error) {
+	// The "origin" remote is always used for the git push/pull the daemon
+	// performs - only webhook activation and API lookups are dispatched
+	// per-provider, via the remote package.
 	origin, err := d.Repository.Remote("origin")
 	if err != nil {
 		return nil, err
@@ -76,18 +93,18 @@ func (d *Deployment) Up(buildType string, stream bool) (*http.Response, error) {
 			Branch:    d.Branch,
 		},
 	}
-	return d.post("/up", reqContent)
+	return d.post(ctx, "/up", reqContent, stream)
 }
 
 // Down brings the project down on the remote VPS instance specified
 // in the configuration object.
-func (d *Deployment) Down() (*http.Response, error) {
-	return d.post("/down", nil)
+func (d *Deployment) Down(ctx context.Context) (*http.Response, error) {
+	return d.post(ctx, "/down", nil, false)
 }
 
 // Status lists the currently active containers on the remote VPS instance
-func (d *Deployment) Status() (*http.Response, error) {
-	resp, err := d.get("/status", nil)
+func (d *Deployment) Status(ctx context.Context) (*http.Response, error) {
+	resp, err := d.get(ctx, "/status", nil, false)
 	if err != nil &&
 		(strings.Contains(err.Error(), "EOF") || strings.Contains(err.Error(), "refused")) {
 		return nil, fmt.Errorf("daemon on remote %s appears offline or inaccessible", d.Name)
@@ -97,50 +114,92 @@ func (d *Deployment) Status() (*http.Response, error) {
 
 // Reset shuts down deployment and deletes the contents of the deployment's
 // project directory
-func (d *Deployment) Reset() (*http.Response, error) {
-	return d.post("/reset", nil)
+func (d *Deployment) Reset(ctx context.Context) (*http.Response, error) {
+	return d.post(ctx, "/reset", nil, false)
 }
 
 // Logs get logs of given container
-func (d *Deployment) Logs(stream bool, container string) (*http.Response, error) {
+func (d *Deployment) Logs(ctx context.Context, stream bool, container string) (*http.Response, error) {
 	reqContent := map[string]string{
 		common.Stream:    strconv.FormatBool(stream),
 		common.Container: container,
 	}
 
-	return d.get("/logs", reqContent)
+	return d.get(ctx, "/logs", reqContent, stream)
+}
+
+// AddUser adds an authorized user for access to Inertia Web. password is
+// hashed with bcrypt before it ever leaves the client, so the daemon
+// never sees - or has to handle - plaintext.
+func (d *Deployment) AddUser(ctx context.Context, username, password string, admin bool) (*http.Response, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	reqContent := &common.UserRequest{
+		Username:     username,
+		PasswordHash: string(hash),
+		Admin:        admin,
+	}
+	return d.post(ctx, "/user/adduser", reqContent, false)
+}
+
+// LogIn authenticates with the daemon, returning an access token in the
+// response body. otp is the current TOTP code and may be left blank for
+// users who have not enabled 2FA.
+func (d *Deployment) LogIn(ctx context.Context, username, password, otp string) (*http.Response, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	reqContent := &common.UserRequest{
+		Username:     username,
+		PasswordHash: string(hash),
+		OTP:          otp,
+	}
+	return d.post(ctx, "/user/login", reqContent, false)
 }
 
-// AddUser adds an authorized user for access to Inertia Web
-func (d *Deployment) AddUser(username, password string, admin bool) (*http.Response, error) {
+// EnableTwoFactor provisions a TOTP secret for a user, requiring a valid
+// --otp code on every subsequent login
+func (d *Deployment) EnableTwoFactor(ctx context.Context, username, totpSecret string) (*http.Response, error) {
 	reqContent := &common.UserRequest{
-		Username: username,
-		Password: password,
-		Admin:    admin,
+		Username:   username,
+		TOTPSecret: totpSecret,
 	}
-	return d.post("/user/adduser", reqContent)
+	return d.post(ctx, "/user/2fa/enable", reqContent, false)
+}
+
+// DisableTwoFactor removes a user's TOTP secret
+func (d *Deployment) DisableTwoFactor(ctx context.Context, username string) (*http.Response, error) {
+	reqContent := &common.UserRequest{Username: username}
+	return d.post(ctx, "/user/2fa/disable", reqContent, false)
 }
 
 // RemoveUser prevents a user from accessing Inertia Web
-func (d *Deployment) RemoveUser(username string) (*http.Response, error) {
+func (d *Deployment) RemoveUser(ctx context.Context, username string) (*http.Response, error) {
 	reqContent := &common.UserRequest{Username: username}
-	return d.post("/user/removeuser", reqContent)
+	return d.post(ctx, "/user/removeuser", reqContent, false)
 }
 
 // ResetUsers resets all users on the remote.
-func (d *Deployment) ResetUsers() (*http.Response, error) {
-	return d.post("/user/resetusers", nil)
+func (d *Deployment) ResetUsers(ctx context.Context) (*http.Response, error) {
+	return d.post(ctx, "/user/resetusers", nil, false)
 }
 
 // ListUsers lists all users on the remote.
-func (d *Deployment) ListUsers() (*http.Response, error) {
-	return d.get("/user/listusers", nil)
+func (d *Deployment) ListUsers(ctx context.Context) (*http.Response, error) {
+	return d.get(ctx, "/user/listusers", nil, false)
 }
 
-// Sends a GET request. "queries" contains query string arguments.
-func (d *Deployment) get(endpoint string, queries map[string]string) (*http.Response, error) {
+// Sends a GET request. "queries" contains query string arguments. stream
+// must be true for long-lived reads (e.g. "logs -f") so the request isn't
+// killed by RequestTimeout - see do.
+func (d *Deployment) get(ctx context.Context, endpoint string, queries map[string]string, stream bool) (*http.Response, error) {
 	// Assemble request
-	req, err := d.buildRequest("GET", endpoint, nil)
+	req, err := d.buildRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -154,11 +213,10 @@ func (d *Deployment) get(endpoint string, queries map[string]string) (*http.Resp
 		req.URL.RawQuery = q.Encode()
 	}
 
-	client := buildHTTPSClient()
-	return client.Do(req)
+	return d.do(req, stream)
 }
 
-func (d *Deployment) post(endpoint string, requestBody interface{}) (*http.Response, error) {
+func (d *Deployment) post(ctx context.Context, endpoint string, requestBody interface{}, stream bool) (*http.Response, error) {
 	// Assemble payload
 	var payload io.Reader
 	if requestBody != nil {
@@ -172,16 +230,63 @@ func (d *Deployment) post(endpoint string, requestBody interface{}) (*http.Respo
 	}
 
 	// Assemble request
-	req, err := d.buildRequest("POST", endpoint, payload)
+	req, err := d.buildRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return nil, err
 	}
 
-	client := buildHTTPSClient()
-	return client.Do(req)
+	return d.do(req, stream)
 }
 
-func (d *Deployment) buildRequest(method string, endpoint string, payload io.Reader) (*http.Request, error) {
+// do issues req against a fresh HTTPS client. Non-streaming requests are
+// bounded by the remote's RequestTimeout (or defaultRequestTimeout) on top
+// of req's own context, so a hung connection can't block forever; the
+// timeout is released once the caller closes the response body, rather
+// than held open for its full duration. Streaming requests (stream=true)
+// rely solely on req's context for cancellation, since a long-lived read
+// (e.g. "logs -f") is expected to outlive any fixed timeout.
+func (d *Deployment) do(req *http.Request, stream bool) (*http.Response, error) {
+	client, err := d.buildHTTPSClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if stream {
+		return client.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), d.requestTimeout())
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnClose{resp.Body, cancel}
+	return resp, nil
+}
+
+// requestTimeout returns the remote's configured RequestTimeout, or
+// defaultRequestTimeout if it hasn't set one
+func (d *Deployment) requestTimeout() time.Duration {
+	if d.RemoteVPS.Daemon.RequestTimeout > 0 {
+		return d.RemoteVPS.Daemon.RequestTimeout
+	}
+	return defaultRequestTimeout
+}
+
+// cancelOnClose wraps a response body so that closing it also releases
+// the context.WithTimeout set up around the request that produced it
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+func (d *Deployment) buildRequest(ctx context.Context, method string, endpoint string, payload io.Reader) (*http.Request, error) {
 	// Assemble URL
 	url, err := url.Parse("https://" + d.RemoteVPS.GetIPAndPort())
 	if err != nil {
@@ -191,7 +296,7 @@ func (d *Deployment) buildRequest(method string, endpoint string, payload io.Rea
 	urlString := url.String()
 
 	// Assemble request
-	req, err := http.NewRequest(method, urlString, payload)
+	req, err := http.NewRequestWithContext(ctx, method, urlString, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -202,15 +307,30 @@ func (d *Deployment) buildRequest(method string, endpoint string, payload io.Rea
 	return req, nil
 }
 
-func buildHTTPSClient() *http.Client {
-	// Make HTTPS request
-	tr := &http.Transport{
-		// Our certificates are self-signed, so will raise
-		// a warning - currently, we ask our client to ignore
-		// this warning.
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: true,
-		},
+// buildHTTPSClient assembles the client used for this deployment's
+// requests. It deliberately leaves Client.Timeout unset - that bounds an
+// entire request including reading the response body, which would kill
+// streaming reads (e.g. "logs -f") as soon as RequestTimeout elapsed
+// regardless of activity. Request-level deadlines are applied by do
+// instead, via context.WithTimeout, only for non-streaming calls.
+//
+// Our daemon certificates are self-signed, so verification is done
+// against the certificate pinned on "inertia remote add" rather than a
+// CA. --insecure skips verification entirely for remotes that were
+// never pinned.
+func (d *Deployment) buildHTTPSClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{}
+	if d.Insecure {
+		tlsConfig.InsecureSkipVerify = true
+	} else {
+		pool, err := trustedCertPool(d.RemoteVPS.Daemon.TrustedCert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
 	}
-	return &http.Client{Transport: tr}
+
+	return &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
 }
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/inertia/cfg"
+)
+
+// newSlowTestDeployment spins up a TLS server that sleeps for delay before
+// responding, and returns a Deployment with RequestTimeout set to timeout.
+func newSlowTestDeployment(t *testing.T, delay, timeout time.Duration) *Deployment {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Deployment{
+		RemoteVPS: &cfg.RemoteVPS{
+			IP:     host,
+			Daemon: &cfg.DaemonConfig{Port: port, RequestTimeout: timeout},
+		},
+		Insecure: true,
+	}
+}
+
+func TestNonStreamingRequest_BoundByRequestTimeout(t *testing.T) {
+	d := newSlowTestDeployment(t, 300*time.Millisecond, 50*time.Millisecond)
+
+	_, err := d.post(context.Background(), "/down", nil, false)
+	assert.NotNil(t, err, "a non-streaming request should be cut off by RequestTimeout")
+}
+
+func TestStreamingRequest_IgnoresRequestTimeout(t *testing.T) {
+	d := newSlowTestDeployment(t, 300*time.Millisecond, 50*time.Millisecond)
+
+	resp, err := d.post(context.Background(), "/up", nil, true)
+	assert.Nil(t, err, "a streaming request must not be cut off by RequestTimeout")
+	if resp != nil {
+		resp.Body.Close()
+	}
+}
+
+func TestStreamingRequest_StillCancelledByContext(t *testing.T) {
+	d := newSlowTestDeployment(t, 300*time.Millisecond, 50*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := d.post(ctx, "/up", nil, true)
+	assert.NotNil(t, err, "a streaming request must still respect ctx cancellation")
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/inertia/cfg"
+	"github.com/ubclaunchpad/inertia/common"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newTestDeployment spins up a TLS server that records the last request
+// body it received and returns a Deployment configured to reach it with
+// certificate verification disabled.
+func newTestDeployment(t *testing.T) (*Deployment, *[]byte) {
+	var lastBody []byte
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastBody, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &Deployment{
+		RemoteVPS: &cfg.RemoteVPS{
+			IP:     host,
+			Daemon: &cfg.DaemonConfig{Port: port},
+		},
+		Insecure: true,
+	}
+
+	return d, &lastBody
+}
+
+func TestAddUser_HashesPasswordClientSide(t *testing.T) {
+	d, lastBody := newTestDeployment(t)
+
+	_, err := d.AddUser(context.Background(), "alice", "hunter2", false)
+	assert.Nil(t, err)
+
+	var req common.UserRequest
+	assert.Nil(t, json.Unmarshal(*lastBody, &req))
+	assert.Equal(t, "alice", req.Username)
+	assert.NotEqual(t, "hunter2", req.PasswordHash)
+	assert.Nil(t, bcrypt.CompareHashAndPassword([]byte(req.PasswordHash), []byte("hunter2")))
+}
+
+func TestLogIn_HashesPasswordClientSide(t *testing.T) {
+	d, lastBody := newTestDeployment(t)
+
+	_, err := d.LogIn(context.Background(), "alice", "hunter2", "123456")
+	assert.Nil(t, err)
+
+	var req common.UserRequest
+	assert.Nil(t, json.Unmarshal(*lastBody, &req))
+	assert.Equal(t, "alice", req.Username)
+	assert.Equal(t, "123456", req.OTP)
+	assert.NotEqual(t, "hunter2", req.PasswordHash)
+	assert.Nil(t, bcrypt.CompareHashAndPassword([]byte(req.PasswordHash), []byte("hunter2")))
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// errNoTrustedCert is returned when a *x509.CertPool is requested for a
+// remote that has not been TOFU-pinned yet
+var errNoTrustedCert = errors.New("remote has no pinned certificate - run 'inertia remote add' again or pass --insecure")
+
+// FetchCertFingerprint dials addr and returns the SHA-256 fingerprint of
+// the leaf certificate it presents, along with the PEM-encoded cert
+// itself. It is used to TOFU-pin a daemon's self-signed certificate the
+// first time a remote is added.
+func FetchCertFingerprint(addr string) (fingerprint string, pemBytes []byte, err error) {
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return "", nil, err
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return "", nil, errors.New("remote presented no certificate")
+	}
+	leaf := certs[0]
+
+	sum := sha256.Sum256(leaf.Raw)
+	fingerprint = hex.EncodeToString(sum[:])
+	pemBytes = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})
+	return fingerprint, pemBytes, nil
+}
+
+// FormatFingerprint renders a SHA-256 fingerprint as colon-separated hex
+// pairs for display, e.g. "aa:bb:cc:..."
+func FormatFingerprint(fingerprint string) string {
+	formatted := ""
+	for i := 0; i < len(fingerprint); i += 2 {
+		if i > 0 {
+			formatted += ":"
+		}
+		formatted += fingerprint[i : i+2]
+	}
+	return formatted
+}
+
+// trustedCertPool builds a CertPool containing only the remote's pinned
+// certificate, so verification fails closed for anything else.
+func trustedCertPool(pemBytes []byte) (*x509.CertPool, error) {
+	if len(pemBytes) == 0 {
+		return nil, errNoTrustedCert
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemBytes) {
+		return nil, fmt.Errorf("failed to parse pinned certificate")
+	}
+	return pool, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatFingerprint(t *testing.T) {
+	assert.Equal(t, "", FormatFingerprint(""))
+	assert.Equal(t, "ab", FormatFingerprint("ab"))
+	assert.Equal(t, "ab:cd:ef", FormatFingerprint("abcdef"))
+}
+
+func TestTrustedCertPool(t *testing.T) {
+	_, err := trustedCertPool(nil)
+	assert.Equal(t, errNoTrustedCert, err)
+
+	_, err = trustedCertPool([]byte("not a cert"))
+	assert.NotNil(t, err)
+
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+	leaf := server.Certificate()
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})
+
+	pool, err := trustedCertPool(pemBytes)
+	assert.Nil(t, err)
+	assert.NotNil(t, pool)
+}
+
+func TestFetchCertFingerprint(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+
+	addr := server.Listener.Addr().String()
+	fingerprint, pemBytes, err := FetchCertFingerprint(addr)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, pemBytes)
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+	assert.Equal(t, hex.EncodeToString(sum[:]), fingerprint)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/ubclaunchpad/inertia/client"
+	"github.com/ubclaunchpad/inertia/local"
+)
+
+var cmdDeploymentTrust = &cobra.Command{
+	Use:   "trust [remote]",
+	Short: "Pin the daemon's TLS certificate for a remote",
+	Long: `Fetches the daemon's self-signed certificate, shows its SHA-256
+fingerprint for confirmation, and pins the certificate to the remote's
+configuration, so future requests are verified against it instead of
+skipping verification.
+
+Use the --rotate flag to re-pin after the daemon's certificate has changed.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		remoteName := args[0]
+		rotate, err := cmd.Flags().GetBool("rotate")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		config, path, err := local.GetProjectConfigFromDisk(configFilePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		remote, found := config.GetRemote(remoteName)
+		if !found {
+			log.Fatalf("remote '%s' not found", remoteName)
+		}
+
+		if remote.Daemon.TrustedCert != nil && !rotate {
+			log.Fatal("remote is already trusted - pass --rotate to re-pin")
+		}
+
+		fingerprint, pemBytes, err := client.FetchCertFingerprint(remote.GetIPAndPort())
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Certificate fingerprint for %s: %s\n", remoteName, client.FormatFingerprint(fingerprint))
+		fmt.Print("Does this match the daemon's certificate? Trust it? [y/N]: ")
+		response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(response)) != "y" {
+			log.Fatal("certificate was not trusted - remote configuration unchanged")
+		}
+
+		remote.Daemon.TrustedCert = pemBytes
+		if err = config.Write(path); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("Remote '%s' is now pinned to this certificate.\n", remoteName)
+	},
+}
+
+func init() {
+	cmdDeploymentTrust.Flags().Bool("rotate", false, "re-pin the daemon's certificate after rotation")
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/mdp/qrterminal/v3"
+	"github.com/pquerna/otp/totp"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/ubclaunchpad/inertia/local"
+)
+
+var cmdDeploymentTwoFactor = &cobra.Command{
+	Use:   "2fa",
+	Short: "Configure two-factor authentication for a user",
+	Long:  `Enables or disables TOTP-based two-factor authentication on Inertia Web.`,
+}
+
+var cmdDeploymentTwoFactorEnable = &cobra.Command{
+	Use:   "enable [user]",
+	Short: "Enable 2FA for a user",
+	Long: `Provisions a TOTP secret for the given user and prints it as an
+otpauth:// URI and QR code to scan into an authenticator app. The secret is
+only persisted once a current code from the app is entered, so a bad scan
+can't lock the user out. Once enabled, 'inertia $REMOTE user login' requires
+a valid --otp code.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		remoteName := strings.Split(cmd.Parent().Parent().Use, " ")[0]
+		deployment, _, err := local.GetClient(remoteName, configFilePath, cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		key, err := totp.Generate(totp.GenerateOpts{
+			Issuer:      "Inertia",
+			AccountName: args[0],
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println("Scan this QR code into your authenticator app:")
+		qrterminal.Generate(key.URL(), qrterminal.M, os.Stdout)
+		fmt.Printf("Or enter this URI manually: %s\n", key.URL())
+
+		fmt.Print("Enter the current code from your authenticator app to confirm: ")
+		code, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		code = strings.TrimSpace(code)
+		if !totp.Validate(code, key.Secret()) {
+			log.Fatal("code did not match - 2FA was not enabled, nothing was saved")
+		}
+
+		ctx, cancel := interruptContext()
+		defer cancel()
+
+		resp, err := deployment.EnableTwoFactor(ctx, args[0], key.Secret())
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			log.WithError(err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			fmt.Printf("(Status code %d) 2FA enabled for %s.\n", resp.StatusCode, args[0])
+		case http.StatusUnauthorized:
+			fmt.Printf("(Status code %d) Bad auth:\n%s\n", resp.StatusCode, body)
+		default:
+			fmt.Printf("(Status code %d) Unknown response from daemon:\n%s\n",
+				resp.StatusCode, body)
+		}
+	},
+}
+
+var cmdDeploymentTwoFactorDisable = &cobra.Command{
+	Use:   "disable [user]",
+	Short: "Disable 2FA for a user",
+	Long:  `Removes the TOTP secret for the given user, no longer requiring --otp at login.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		remoteName := strings.Split(cmd.Parent().Parent().Use, " ")[0]
+		deployment, _, err := local.GetClient(remoteName, configFilePath, cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx, cancel := interruptContext()
+		defer cancel()
+
+		resp, err := deployment.DisableTwoFactor(ctx, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			log.WithError(err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			fmt.Printf("(Status code %d) 2FA disabled for %s.\n", resp.StatusCode, args[0])
+		case http.StatusUnauthorized:
+			fmt.Printf("(Status code %d) Bad auth:\n%s\n", resp.StatusCode, body)
+		default:
+			fmt.Printf("(Status code %d) Unknown response from daemon:\n%s\n",
+				resp.StatusCode, body)
+		}
+	},
+}
+
+func init() {
+	cmdDeploymentTwoFactor.AddCommand(cmdDeploymentTwoFactorEnable)
+	cmdDeploymentTwoFactor.AddCommand(cmdDeploymentTwoFactorDisable)
+	cmdDeploymentUser.AddCommand(cmdDeploymentTwoFactor)
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"os/signal"
 	"strings"
 	"syscall"
 
@@ -13,6 +16,13 @@ import (
 	"golang.org/x/crypto/ssh/terminal"
 )
 
+// interruptContext returns a context that is cancelled when the user hits
+// Ctrl-C, so an in-flight daemon request gets torn down instead of left
+// to hang.
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
 var cmdDeploymentUser = &cobra.Command{
 	Use:   "user",
 	Short: "Configure user access to Inertia Web",
@@ -40,6 +50,9 @@ Use the --admin flag to create an admin user.`,
 			log.Fatal(err)
 		}
 
+		ctx, cancel := interruptContext()
+		defer cancel()
+
 		fmt.Print("Enter a password for user: ")
 		bytePassword, err := terminal.ReadPassword(int(syscall.Stdin))
 		if err != nil {
@@ -48,7 +61,7 @@ Use the --admin flag to create an admin user.`,
 		password := strings.TrimSpace(string(bytePassword))
 		fmt.Print("\n")
 
-		resp, err := deployment.AddUser(args[0], password, admin)
+		resp, err := deployment.AddUser(ctx, args[0], password, admin)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -85,7 +98,10 @@ from the web app.`,
 			log.Fatal(err)
 		}
 
-		resp, err := deployment.RemoveUser(args[0])
+		ctx, cancel := interruptContext()
+		defer cancel()
+
+		resp, err := deployment.RemoveUser(ctx, args[0])
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -128,7 +144,15 @@ var cmdDeploymentLogin = &cobra.Command{
 			log.Fatal(err)
 		}
 
-		resp, err := deployment.LogIn(username, string(pwBytes))
+		otp, err := cmd.Flags().GetString("otp")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx, cancel := interruptContext()
+		defer cancel()
+
+		resp, err := deployment.LogIn(ctx, username, string(pwBytes), otp)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -172,7 +196,10 @@ be able to log in and view or configure the deployment from the web app.`,
 			log.Fatal(err)
 		}
 
-		resp, err := deployment.ResetUsers()
+		ctx, cancel := interruptContext()
+		defer cancel()
+
+		resp, err := deployment.ResetUsers(ctx)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -206,7 +233,10 @@ var cmdDeploymentListUsers = &cobra.Command{
 			log.Fatal(err)
 		}
 
-		resp, err := deployment.ListUsers()
+		ctx, cancel := interruptContext()
+		defer cancel()
+
+		resp, err := deployment.ListUsers(ctx)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -228,3 +258,7 @@ var cmdDeploymentListUsers = &cobra.Command{
 		}
 	},
 }
+
+func init() {
+	cmdDeploymentLogin.Flags().String("otp", "", "current TOTP code, required if 2FA is enabled on this user")
+}
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"regexp"
+
+	git "gopkg.in/src-d/go-git.v4"
+)
+
+// sshURLPattern matches the https://host/owner/repo(.git) form so
+// GetSSHRemoteURL can rewrite it to git@host:owner/repo.git
+var sshURLPattern = regexp.MustCompile(`^https?://([^/]+)/(.+?)(\.git)?$`)
+
+// GetSSHRemoteURL converts an HTTPS git remote URL into its SSH
+// equivalent; URLs already in SSH form are returned unchanged
+func GetSSHRemoteURL(url string) string {
+	matches := sshURLPattern.FindStringSubmatch(url)
+	if matches == nil {
+		return url
+	}
+	return "git@" + matches[1] + ":" + matches[2] + ".git"
+}
+
+// GetLocalRepo opens the git repository rooted at the current working
+// directory
+func GetLocalRepo() (*git.Repository, error) {
+	return git.PlainOpen(".")
+}
+
+// GenerateRandomString returns a base64-encoded, cryptographically random
+// 32-byte string, suitable for use as a webhook secret
+func GenerateRandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
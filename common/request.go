@@ -0,0 +1,41 @@
+package common
+
+// Query string keys used by Deployment.Logs
+const (
+	Stream    = "stream"
+	Container = "container"
+)
+
+// GitOptions describes which repository and branch the daemon should
+// deploy from
+type GitOptions struct {
+	RemoteURL string `json:"remote_url"`
+	Branch    string `json:"branch"`
+}
+
+// DaemonRequest is the payload sent to the daemon's "/up" endpoint
+type DaemonRequest struct {
+	Stream     bool        `json:"stream"`
+	Project    string      `json:"project"`
+	BuildType  string      `json:"build_type"`
+	Secret     string      `json:"secret"`
+	GitOptions *GitOptions `json:"git_options"`
+}
+
+// UserRequest is the payload sent to the daemon's /user endpoints
+type UserRequest struct {
+	Username string `json:"username"`
+	Admin    bool   `json:"admin"`
+
+	// PasswordHash is a bcrypt hash computed client-side - the daemon
+	// never receives a plaintext password, even transiently.
+	PasswordHash string `json:"password_hash,omitempty"`
+
+	// TOTPSecret provisions or rotates a user's TOTP secret when set on
+	// a /user/2fa/enable request
+	TOTPSecret string `json:"totp_secret,omitempty"`
+
+	// OTP is the current TOTP code supplied on login, required when the
+	// user has 2FA enabled
+	OTP string `json:"otp,omitempty"`
+}
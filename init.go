@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var cmdInit = &cobra.Command{
+	Use:   "init",
+	Short: "Initialize this project for Inertia deployments",
+	Long: `Sets up this project's inertia.toml with a name and build type.
+
+With no flags, walks through an interactive setup. Passing --build-type
+(and --buildfile, unless --build-type is "herokuish") skips the
+walkthrough and uses the given values instead.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, _ := cmd.Flags().GetString("config")
+		config, err := loadOrInitConfig(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		flags := projectFlags{}
+		flags.BuildType, _ = cmd.Flags().GetString("build-type")
+		flags.BuildFilePath, _ = cmd.Flags().GetString("buildfile")
+
+		var buildType, buildFilePath string
+		if flags.IsSet() {
+			buildType, buildFilePath, err = addProjectFromFlags(flags)
+		} else {
+			buildType, buildFilePath, err = addProjectWalkthrough(os.Stdin)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if config.Project == "" {
+			wd, err := os.Getwd()
+			if err != nil {
+				log.Fatal(err)
+			}
+			config.Project = filepath.Base(wd)
+		}
+		config.BuildType = buildType
+		config.BuildFilePath = buildFilePath
+
+		if err = config.Write(path); err != nil {
+			log.Fatal(err)
+		}
+		log.Infof("project '%s' initialized in %s", config.Project, path)
+	},
+}
+
+func init() {
+	cmdInit.Flags().String("build-type", "", "project build type - docker-compose, dockerfile, or herokuish")
+	cmdInit.Flags().String("buildfile", "", "path to the build file (e.g. docker-compose.yml), required unless --build-type is herokuish")
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ubclaunchpad/inertia/cfg"
 	"github.com/ubclaunchpad/inertia/common"
+	"github.com/ubclaunchpad/inertia/remote"
 )
 
 var (
@@ -16,14 +17,26 @@ var (
 	errInvalidAddress       = errors.New("invalid IP address")
 	errInvalidBuildType     = errors.New("invalid build type")
 	errInvalidBuildFilePath = errors.New("invalid buildfile path")
+	errInvalidProvider      = errors.New("invalid remote provider")
+	errInvalidDaemonConfig  = errors.New("invalid or missing daemon config")
 )
 
 // addRemoteWalkthough is the command line walkthrough that asks
 // users for RemoteVPS details. It is up to the caller to save config.
+// provider selects the Remote driver (see the remote package) used to
+// activate webhooks on this remote; it defaults to remote.GitHub when
+// left blank.
 func addRemoteWalkthrough(
 	in io.Reader, config *cfg.Config,
-	name, port, sshPort, currBranch string,
+	name, port, sshPort, currBranch, provider string,
 ) error {
+	if provider == "" {
+		provider = string(remote.GitHub)
+	}
+	if _, err := remote.New(remote.Provider(provider)); err != nil {
+		return errInvalidProvider
+	}
+
 	homeEnvVar := os.Getenv("HOME")
 	sshDir := filepath.Join(homeEnvVar, ".ssh")
 	defaultSSHLoc := filepath.Join(sshDir, "id_rsa")
@@ -72,18 +85,24 @@ func addRemoteWalkthrough(
 	fmt.Println("Run 'inertia remote add' with the -p flag to set a custom Daemon port")
 	fmt.Println("of the -ssh flag to set a custom SSH port.")
 
-	config.AddRemote(&cfg.RemoteVPS{
-		Name:    name,
-		IP:      address,
-		User:    user,
-		PEM:     pemLoc,
-		Branch:  branch,
-		SSHPort: sshPort,
+	remoteVPS := &cfg.RemoteVPS{
+		Name:     name,
+		IP:       address,
+		User:     user,
+		PEM:      pemLoc,
+		Branch:   branch,
+		SSHPort:  sshPort,
+		Provider: provider,
 		Daemon: &cfg.DaemonConfig{
 			Port:   port,
 			Secret: secret,
 		},
-	})
+	}
+	if err := pinCertificate(in, remoteVPS, true); err != nil {
+		return err
+	}
+
+	config.AddRemote(remoteVPS)
 	return nil
 }
 
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ubclaunchpad/inertia/cfg"
+	"github.com/ubclaunchpad/inertia/common"
+	"github.com/ubclaunchpad/inertia/remote"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// remoteFlags holds the --pem/--user/--ip/--secret/--branch/--provider
+// values accepted by "inertia remote add" as an alternative to the
+// interactive walkthrough. Any of these being set is enough to bypass
+// addRemoteWalkthrough entirely.
+type remoteFlags struct {
+	PEM      string
+	User     string
+	IP       string
+	Secret   string
+	Branch   string
+	Provider string
+}
+
+// IsSet reports whether any non-interactive remote flag was supplied
+func (f remoteFlags) IsSet() bool {
+	return f.PEM != "" || f.User != "" || f.IP != "" || f.Secret != "" ||
+		f.Branch != "" || f.Provider != ""
+}
+
+// projectFlags holds the --build-type/--buildfile values accepted by
+// "inertia init" as an alternative to the interactive walkthrough.
+type projectFlags struct {
+	BuildType     string
+	BuildFilePath string
+}
+
+// IsSet reports whether any non-interactive project flag was supplied
+func (f projectFlags) IsSet() bool {
+	return f.BuildType != "" || f.BuildFilePath != ""
+}
+
+// addRemoteFromFlags mirrors addRemoteWalkthrough, but takes its values
+// from flags instead of prompting on in. It is up to the caller to save
+// config.
+func addRemoteFromFlags(
+	config *cfg.Config,
+	name, port, sshPort, currBranch string,
+	flags remoteFlags,
+) error {
+	if flags.User == "" {
+		return errInvalidUser
+	}
+	if flags.IP == "" {
+		return errInvalidAddress
+	}
+
+	pemLoc := flags.PEM
+	if pemLoc == "" {
+		pemLoc = defaultPEMLocation()
+	}
+
+	secret := flags.Secret
+	if secret == "" {
+		var err error
+		secret, err = common.GenerateRandomString()
+		if err != nil {
+			return err
+		}
+	}
+
+	provider := flags.Provider
+	if provider == "" {
+		provider = string(remote.GitHub)
+	}
+	if _, err := remote.New(remote.Provider(provider)); err != nil {
+		return errInvalidProvider
+	}
+
+	branch := currBranch
+	if flags.Branch != "" {
+		branch = flags.Branch
+	}
+
+	remoteVPS := &cfg.RemoteVPS{
+		Name:     name,
+		IP:       flags.IP,
+		User:     flags.User,
+		PEM:      pemLoc,
+		Branch:   branch,
+		SSHPort:  sshPort,
+		Provider: provider,
+		Daemon: &cfg.DaemonConfig{
+			Port:   port,
+			Secret: secret,
+		},
+	}
+	if err := pinCertificate(nil, remoteVPS, false); err != nil {
+		return err
+	}
+
+	config.AddRemote(remoteVPS)
+	return nil
+}
+
+// addRemoteFromFile reads a YAML file shaped like cfg.RemoteVPS and adds
+// it to config. It is up to the caller to save config.
+func addRemoteFromFile(config *cfg.Config, filePath string) error {
+	raw, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	var remoteVPS cfg.RemoteVPS
+	if err := yaml.Unmarshal(raw, &remoteVPS); err != nil {
+		return err
+	}
+
+	if remoteVPS.User == "" {
+		return errInvalidUser
+	}
+	if remoteVPS.IP == "" {
+		return errInvalidAddress
+	}
+	if remoteVPS.Daemon == nil {
+		return errInvalidDaemonConfig
+	}
+	if remoteVPS.Provider == "" {
+		remoteVPS.Provider = string(remote.GitHub)
+	}
+	if _, err := remote.New(remote.Provider(remoteVPS.Provider)); err != nil {
+		return errInvalidProvider
+	}
+	if err := pinCertificate(nil, &remoteVPS, false); err != nil {
+		return err
+	}
+
+	config.AddRemote(&remoteVPS)
+	return nil
+}
+
+// addProjectFromFlags mirrors addProjectWalkthrough, but takes its values
+// from flags instead of prompting on in.
+func addProjectFromFlags(flags projectFlags) (buildType string, buildFilePath string, inputErr error) {
+	if flags.BuildType == "" {
+		return "", "", errInvalidBuildType
+	}
+	buildType = flags.BuildType
+
+	if buildType == "herokuish" {
+		return
+	}
+	if flags.BuildFilePath == "" {
+		return "", "", errInvalidBuildFilePath
+	}
+	buildFilePath = flags.BuildFilePath
+	return
+}
+
+func defaultPEMLocation() string {
+	sshDir := filepath.Join(os.Getenv("HOME"), ".ssh")
+	return filepath.Join(sshDir, "id_rsa")
+}
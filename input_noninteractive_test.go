@@ -0,0 +1,139 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/inertia/cfg"
+)
+
+func TestRemoteFlagsIsSet(t *testing.T) {
+	assert.False(t, remoteFlags{}.IsSet())
+	assert.True(t, remoteFlags{User: "bob"}.IsSet())
+	assert.True(t, remoteFlags{Provider: "gitlab"}.IsSet())
+}
+
+func TestProjectFlagsIsSet(t *testing.T) {
+	assert.False(t, projectFlags{}.IsSet())
+	assert.True(t, projectFlags{BuildType: "herokuish"}.IsSet())
+}
+
+func TestAddRemoteFromFlags_Validation(t *testing.T) {
+	config := &cfg.Config{Remotes: map[string]*cfg.RemoteVPS{}}
+
+	err := addRemoteFromFlags(config, "prod", "4303", "22", "main", remoteFlags{})
+	assert.Equal(t, errInvalidUser, err)
+
+	err = addRemoteFromFlags(config, "prod", "4303", "22", "main", remoteFlags{User: "bob"})
+	assert.Equal(t, errInvalidAddress, err)
+
+	err = addRemoteFromFlags(config, "prod", "4303", "22", "main", remoteFlags{
+		User: "bob", IP: "10.0.0.1", Provider: "not-a-real-provider",
+	})
+	assert.Equal(t, errInvalidProvider, err)
+}
+
+func TestAddRemoteFromFlags_Success(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.Nil(t, err)
+
+	config := &cfg.Config{Remotes: map[string]*cfg.RemoteVPS{}}
+	err = addRemoteFromFlags(config, "prod", port, "22", "main", remoteFlags{
+		User: "bob", IP: host,
+	})
+	assert.Nil(t, err)
+
+	remote, found := config.GetRemote("prod")
+	assert.True(t, found)
+	assert.Equal(t, "github", remote.Provider)
+	assert.NotEmpty(t, remote.Daemon.Secret)
+	assert.NotEmpty(t, remote.Daemon.TrustedCert)
+}
+
+func TestAddRemoteFromFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "remote.yml")
+	contents := "name: prod\nip: " + host + "\nuser: bob\nbranch: main\ndaemon:\n  port: \"" + port + "\"\n  secret: shh\n"
+	assert.Nil(t, ioutil.WriteFile(yamlPath, []byte(contents), 0644))
+
+	config := &cfg.Config{Remotes: map[string]*cfg.RemoteVPS{}}
+	assert.Nil(t, addRemoteFromFile(config, yamlPath))
+
+	remote, found := config.GetRemote("prod")
+	assert.True(t, found)
+	assert.Equal(t, "bob", remote.User)
+	assert.NotEmpty(t, remote.Daemon.TrustedCert)
+}
+
+func TestAddRemoteFromFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "remote.yml")
+	assert.Nil(t, ioutil.WriteFile(yamlPath, []byte("ip: 10.0.0.1\n"), 0644))
+
+	config := &cfg.Config{Remotes: map[string]*cfg.RemoteVPS{}}
+	err := addRemoteFromFile(config, yamlPath)
+	assert.Equal(t, errInvalidUser, err)
+}
+
+func TestAddRemoteFromFile_MissingDaemonConfig(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "remote.yml")
+	contents := "name: prod\nip: 10.0.0.1\nuser: bob\nbranch: main\n"
+	assert.Nil(t, ioutil.WriteFile(yamlPath, []byte(contents), 0644))
+
+	config := &cfg.Config{Remotes: map[string]*cfg.RemoteVPS{}}
+	err := addRemoteFromFile(config, yamlPath)
+	assert.Equal(t, errInvalidDaemonConfig, err)
+}
+
+func TestAddProjectFromFlags(t *testing.T) {
+	buildType, buildFilePath, err := addProjectFromFlags(projectFlags{BuildType: "herokuish"})
+	assert.Nil(t, err)
+	assert.Equal(t, "herokuish", buildType)
+	assert.Equal(t, "", buildFilePath)
+
+	_, _, err = addProjectFromFlags(projectFlags{BuildType: "docker-compose"})
+	assert.Equal(t, errInvalidBuildFilePath, err)
+
+	buildType, buildFilePath, err = addProjectFromFlags(projectFlags{
+		BuildType: "docker-compose", BuildFilePath: "docker-compose.yml",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "docker-compose", buildType)
+	assert.Equal(t, "docker-compose.yml", buildFilePath)
+
+	_, _, err = addProjectFromFlags(projectFlags{})
+	assert.Equal(t, errInvalidBuildType, err)
+}
+
+func TestLoadOrInitConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "inertia.toml")
+
+	config, err := loadOrInitConfig(path)
+	assert.Nil(t, err)
+	assert.NotNil(t, config.Remotes)
+
+	config.Project = "test"
+	assert.Nil(t, config.Write(path))
+
+	_, err = os.Stat(path)
+	assert.Nil(t, err)
+
+	reloaded, err := loadOrInitConfig(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "test", reloaded.Project)
+}
@@ -0,0 +1,63 @@
+// Package local resolves the project config and Deployment client a CLI
+// command should operate on, given the remote name and flags a user
+// passed on the command line.
+package local
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	"github.com/ubclaunchpad/inertia/cfg"
+	"github.com/ubclaunchpad/inertia/client"
+	"github.com/ubclaunchpad/inertia/common"
+)
+
+// errRemoteNotFound is returned by GetClient when the named remote isn't
+// in the project's inertia.toml
+var errRemoteNotFound = errors.New("remote not found")
+
+// GetProjectConfigFromDisk reads the project's inertia.toml from
+// configFilePath, returning the parsed config and the path it was read
+// from so callers can write it back out after mutating it
+func GetProjectConfigFromDisk(configFilePath string) (*cfg.Config, string, error) {
+	config, err := cfg.Load(configFilePath)
+	if err != nil {
+		return nil, "", err
+	}
+	return config, configFilePath, nil
+}
+
+// GetClient builds the Deployment client for remoteName, applying the
+// --insecure flag from cmd if present
+func GetClient(remoteName, configFilePath string, cmd *cobra.Command) (*client.Deployment, *cfg.Config, error) {
+	config, _, err := GetProjectConfigFromDisk(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remote, found := config.GetRemote(remoteName)
+	if !found {
+		return nil, nil, errRemoteNotFound
+	}
+
+	repo, err := common.GetLocalRepo()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	insecure := false
+	if cmd != nil {
+		if v, err := cmd.Flags().GetBool("insecure"); err == nil {
+			insecure = v
+		}
+	}
+
+	return &client.Deployment{
+		RemoteVPS:  remote,
+		Repository: repo,
+		Auth:       remote.Daemon.Token,
+		BuildType:  config.BuildType,
+		Project:    config.Project,
+		Insecure:   insecure,
+	}, config, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// configFilePath is the path to the project's inertia.toml, set by the
+// --config flag on rootCmd
+var configFilePath string
+
+var rootCmd = &cobra.Command{
+	Use:   "inertia",
+	Short: "Effortless, self-hosted continuous deployment for small teams and projects",
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&configFilePath, "config", "c", "inertia.toml",
+		"path to this project's inertia.toml")
+	rootCmd.PersistentFlags().Bool("insecure", false,
+		"skip daemon certificate verification instead of checking it against the pinned cert")
+	rootCmd.AddCommand(cmdRemote)
+	rootCmd.AddCommand(cmdInit)
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		log.Fatal(err)
+	}
+}
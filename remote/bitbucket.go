@@ -0,0 +1,54 @@
+package remote
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// bitbucketRemote talks to bitbucket.org and verifies Bitbucket webhooks
+type bitbucketRemote struct{}
+
+func (r *bitbucketRemote) Activate(repo, webhookURL, secret string) error {
+	// TODO: POST to https://api.bitbucket.org/2.0/repositories/{repo}/hooks
+	return errNotImplemented
+}
+
+func (r *bitbucketRemote) Status(repo string) error {
+	// TODO: GET https://api.bitbucket.org/2.0/repositories/{repo}
+	return errNotImplemented
+}
+
+// Hook verifies the X-Hub-Signature header Bitbucket Cloud sends when a
+// webhook is configured with a secret (Bitbucket reuses GitHub's
+// sha1=<hex> convention here).
+func (r *bitbucketRemote) Hook(req *http.Request, secret string) (string, error) {
+	sig := req.Header.Get("X-Hub-Signature")
+	if sig == "" {
+		return "", errMissingSignature
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	if !verifySHA1(secret, strings.TrimPrefix(sig, "sha1="), body) {
+		return "", errBadSignature
+	}
+
+	return extractBranch(req.Header.Get("X-Event-Key"), body)
+}
+
+func (r *bitbucketRemote) Netrc(token string) (string, string, string) {
+	return "bitbucket.org", "x-token-auth", token
+}
+
+func (r *bitbucketRemote) Refresh(token string) (string, error) {
+	// TODO: POST https://bitbucket.org/site/oauth2/access_token with refresh_token grant
+	return "", errNotImplemented
+}
+
+func (r *bitbucketRemote) Repos(token string) ([]string, error) {
+	// TODO: GET https://api.bitbucket.org/2.0/repositories?role=member
+	return nil, errNotImplemented
+}
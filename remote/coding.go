@@ -0,0 +1,52 @@
+package remote
+
+import (
+	"crypto/subtle"
+	"io/ioutil"
+	"net/http"
+)
+
+// codingRemote talks to Coding.net and verifies Coding webhooks
+type codingRemote struct{}
+
+func (r *codingRemote) Activate(repo, webhookURL, secret string) error {
+	// TODO: POST to https://e.coding.net/open-api with Action=CreateWebhook
+	return errNotImplemented
+}
+
+func (r *codingRemote) Status(repo string) error {
+	// TODO: POST to https://e.coding.net/open-api with Action=DescribeProject
+	return errNotImplemented
+}
+
+// Hook verifies the X-Coding-Token header, which Coding sets to the
+// plaintext webhook secret.
+func (r *codingRemote) Hook(req *http.Request, secret string) (string, error) {
+	token := req.Header.Get("X-Coding-Token")
+	if token == "" {
+		return "", errMissingSignature
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return "", errBadSignature
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	return extractBranch(req.Header.Get("X-Coding-Event"), body)
+}
+
+func (r *codingRemote) Netrc(token string) (string, string, string) {
+	return "coding.net", "oauth2", token
+}
+
+func (r *codingRemote) Refresh(token string) (string, error) {
+	// TODO: POST https://e.coding.net/api/oauth/access_token with refresh_token grant
+	return "", errNotImplemented
+}
+
+func (r *codingRemote) Repos(token string) ([]string, error) {
+	// TODO: POST to https://e.coding.net/open-api with Action=DescribeUserProjects
+	return nil, errNotImplemented
+}
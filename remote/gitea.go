@@ -0,0 +1,52 @@
+package remote
+
+import (
+	"io/ioutil"
+	"net/http"
+)
+
+// giteaRemote talks to a Gitea instance and verifies Gitea webhooks
+type giteaRemote struct{}
+
+func (r *giteaRemote) Activate(repo, webhookURL, secret string) error {
+	// TODO: POST to {giteaHost}/api/v1/repos/{repo}/hooks
+	return errNotImplemented
+}
+
+func (r *giteaRemote) Status(repo string) error {
+	// TODO: GET {giteaHost}/api/v1/repos/{repo}
+	return errNotImplemented
+}
+
+// Hook verifies the X-Gitea-Signature header, an HMAC-SHA256 of the
+// request body computed with the webhook secret.
+func (r *giteaRemote) Hook(req *http.Request, secret string) (string, error) {
+	sig := req.Header.Get("X-Gitea-Signature")
+	if sig == "" {
+		return "", errMissingSignature
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	if !verifySHA256(secret, sig, body) {
+		return "", errBadSignature
+	}
+
+	return extractBranch(req.Header.Get("X-Gitea-Event"), body)
+}
+
+func (r *giteaRemote) Netrc(token string) (string, string, string) {
+	return "", "token", token
+}
+
+func (r *giteaRemote) Refresh(token string) (string, error) {
+	// Gitea access tokens don't expire via a refresh flow
+	return token, nil
+}
+
+func (r *giteaRemote) Repos(token string) ([]string, error) {
+	// TODO: GET {giteaHost}/api/v1/user/repos
+	return nil, errNotImplemented
+}
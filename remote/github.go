@@ -0,0 +1,57 @@
+package remote
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// githubRemote talks to github.com and verifies github.com webhooks
+type githubRemote struct{}
+
+func (r *githubRemote) Activate(repo, webhookURL, secret string) error {
+	// TODO: POST to https://api.github.com/repos/{repo}/hooks
+	return errNotImplemented
+}
+
+func (r *githubRemote) Status(repo string) error {
+	// TODO: GET https://api.github.com/repos/{repo}
+	return errNotImplemented
+}
+
+// Hook verifies the X-Hub-Signature-256 (falling back to the legacy
+// X-Hub-Signature) header GitHub sends on every webhook delivery.
+func (r *githubRemote) Hook(req *http.Request, secret string) (string, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if sig := req.Header.Get("X-Hub-Signature-256"); sig != "" {
+		if !verifySHA256(secret, strings.TrimPrefix(sig, "sha256="), body) {
+			return "", errBadSignature
+		}
+	} else if sig := req.Header.Get("X-Hub-Signature"); sig != "" {
+		if !verifySHA1(secret, strings.TrimPrefix(sig, "sha1="), body) {
+			return "", errBadSignature
+		}
+	} else {
+		return "", errMissingSignature
+	}
+
+	return extractBranch(req.Header.Get("X-GitHub-Event"), body)
+}
+
+func (r *githubRemote) Netrc(token string) (string, string, string) {
+	return "github.com", "x-access-token", token
+}
+
+func (r *githubRemote) Refresh(token string) (string, error) {
+	// GitHub personal/app tokens don't expire via a refresh flow
+	return token, nil
+}
+
+func (r *githubRemote) Repos(token string) ([]string, error) {
+	// TODO: GET https://api.github.com/user/repos
+	return nil, errNotImplemented
+}
@@ -0,0 +1,53 @@
+package remote
+
+import (
+	"crypto/subtle"
+	"io/ioutil"
+	"net/http"
+)
+
+// gitlabRemote talks to gitlab.com (or a self-hosted GitLab) and verifies
+// GitLab webhooks
+type gitlabRemote struct{}
+
+func (r *gitlabRemote) Activate(repo, webhookURL, secret string) error {
+	// TODO: POST to {gitlabHost}/api/v4/projects/{repo}/hooks
+	return errNotImplemented
+}
+
+func (r *gitlabRemote) Status(repo string) error {
+	// TODO: GET {gitlabHost}/api/v4/projects/{repo}
+	return errNotImplemented
+}
+
+// Hook verifies the X-Gitlab-Token header, which GitLab sets to the
+// plaintext secret configured on the webhook rather than an HMAC.
+func (r *gitlabRemote) Hook(req *http.Request, secret string) (string, error) {
+	token := req.Header.Get("X-Gitlab-Token")
+	if token == "" {
+		return "", errMissingSignature
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return "", errBadSignature
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	return extractBranch(req.Header.Get("X-Gitlab-Event"), body)
+}
+
+func (r *gitlabRemote) Netrc(token string) (string, string, string) {
+	return "gitlab.com", "oauth2", token
+}
+
+func (r *gitlabRemote) Refresh(token string) (string, error) {
+	// TODO: POST {gitlabHost}/oauth/token with grant_type=refresh_token
+	return "", errNotImplemented
+}
+
+func (r *gitlabRemote) Repos(token string) ([]string, error) {
+	// TODO: GET {gitlabHost}/api/v4/projects?membership=true
+	return nil, errNotImplemented
+}
@@ -0,0 +1,149 @@
+// Package remote abstracts over the Git hosting providers Inertia can
+// deploy from. Each provider verifies its own webhooks in Hook and
+// supplies its own Netrc credentials; Activate, Status and Repos, and
+// Refresh for providers whose tokens expire, return errNotImplemented.
+package remote
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+// Provider identifies a supported Git hosting platform
+type Provider string
+
+// Supported providers
+const (
+	GitHub    Provider = "github"
+	GitLab    Provider = "gitlab"
+	Bitbucket Provider = "bitbucket"
+	Gitea     Provider = "gitea"
+	Coding    Provider = "coding"
+)
+
+var (
+	// errUnknownProvider is returned by New when given a provider with
+	// no registered driver
+	errUnknownProvider = errors.New("unknown remote provider")
+
+	// errBadSignature is returned by Hook when the computed HMAC does
+	// not match the signature on the request
+	errBadSignature = errors.New("webhook signature mismatch")
+
+	// errMissingSignature is returned by Hook when a provider's
+	// signature header is absent from the request
+	errMissingSignature = errors.New("webhook request missing signature header")
+
+	// errNotImplemented is returned by the API calls each driver has not
+	// yet implemented, so a caller checking err == nil can't mistake a
+	// no-op stub for success
+	errNotImplemented = errors.New("not implemented")
+)
+
+// Remote is implemented by each supported Git hosting provider. It covers
+// everything Inertia needs to do with a remote besides raw git operations,
+// which continue to go through go-git directly.
+type Remote interface {
+	// Activate registers the deploy key and webhook needed to drive
+	// deployments from this remote
+	Activate(repo, webhookURL, secret string) error
+
+	// Status reports whether the remote is reachable and the webhook
+	// is still active
+	Status(repo string) error
+
+	// Hook verifies and parses an inbound webhook request, returning
+	// the branch the event targets
+	Hook(req *http.Request, secret string) (branch string, err error)
+
+	// Netrc returns the machine/login/password triple to write to
+	// ~/.netrc on the daemon so git can authenticate over HTTPS
+	Netrc(token string) (machine, login, password string)
+
+	// Refresh exchanges a refresh token for a new access token, for
+	// providers whose tokens expire
+	Refresh(token string) (string, error)
+
+	// Repos lists the repositories the authenticated user can deploy
+	Repos(token string) ([]string, error)
+}
+
+// New constructs the Remote driver for the given provider
+func New(provider Provider) (Remote, error) {
+	switch provider {
+	case GitHub:
+		return &githubRemote{}, nil
+	case GitLab:
+		return &gitlabRemote{}, nil
+	case Bitbucket:
+		return &bitbucketRemote{}, nil
+	case Gitea:
+		return &giteaRemote{}, nil
+	case Coding:
+		return &codingRemote{}, nil
+	default:
+		return nil, errUnknownProvider
+	}
+}
+
+// Providers lists every provider name accepted by the --provider flag
+func Providers() []string {
+	return []string{
+		string(GitHub), string(GitLab), string(Bitbucket), string(Gitea), string(Coding),
+	}
+}
+
+// verifyHMAC compares sig (hex-encoded) against an HMAC of body computed
+// with secret, using the given hash constructor. It underlies the
+// per-provider signature checks in Hook. A sig that isn't valid hex is
+// treated as a mismatch rather than an error, since callers only care
+// whether the signature is trustworthy.
+func verifyHMAC(newHash func() hash.Hash, secret, sig string, body []byte) bool {
+	decoded, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	return hmac.Equal(expected, decoded)
+}
+
+func verifySHA1(secret, sig string, body []byte) bool {
+	return verifyHMAC(sha1.New, secret, sig, body)
+}
+
+func verifySHA256(secret, sig string, body []byte) bool {
+	return verifyHMAC(sha256.New, secret, sig, body)
+}
+
+// extractBranch pulls the target branch out of a push/PR/tag event body.
+// Every provider we support puts the ref on a "ref" (push/tag) or
+// "pull_request.base.ref" (PR) field, so a single best-effort parse
+// covers all of them.
+func extractBranch(event string, body []byte) (string, error) {
+	var payload struct {
+		Ref         string `json:"ref"`
+		PullRequest struct {
+			Base struct {
+				Ref string `json:"ref"`
+			} `json:"base"`
+		} `json:"pull_request"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", err
+	}
+
+	if payload.PullRequest.Base.Ref != "" {
+		return payload.PullRequest.Base.Ref, nil
+	}
+	return strings.TrimPrefix(payload.Ref, "refs/heads/"), nil
+}
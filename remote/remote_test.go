@@ -0,0 +1,139 @@
+package remote
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubHook(t *testing.T) {
+	r := &githubRemote{}
+	secret := "shh"
+	body := []byte(`{"ref":"refs/heads/main"}`)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	sig := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(string(body)))
+	req.Header.Set("X-Hub-Signature-256", sig)
+	req.Header.Set("X-GitHub-Event", "push")
+
+	branch, err := r.Hook(req, secret)
+	assert.Nil(t, err)
+	assert.Equal(t, "main", branch)
+}
+
+func TestGitHubHook_BadSignature(t *testing.T) {
+	r := &githubRemote{}
+	body := []byte(`{"ref":"refs/heads/main"}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(string(body)))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+strings.Repeat("0", 64))
+	req.Header.Set("X-GitHub-Event", "push")
+
+	_, err := r.Hook(req, "shh")
+	assert.Equal(t, errBadSignature, err)
+}
+
+func TestGitHubHook_MissingSignature(t *testing.T) {
+	r := &githubRemote{}
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("{}"))
+	req.Header.Set("X-GitHub-Event", "push")
+
+	_, err := r.Hook(req, "shh")
+	assert.Equal(t, errMissingSignature, err)
+}
+
+func TestGitLabHook(t *testing.T) {
+	r := &gitlabRemote{}
+	secret := "shh"
+	body := []byte(`{"ref":"refs/heads/develop"}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(string(body)))
+	req.Header.Set("X-Gitlab-Token", secret)
+	req.Header.Set("X-Gitlab-Event", "push")
+
+	branch, err := r.Hook(req, secret)
+	assert.Nil(t, err)
+	assert.Equal(t, "develop", branch)
+}
+
+func TestGitLabHook_BadToken(t *testing.T) {
+	r := &gitlabRemote{}
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("{}"))
+	req.Header.Set("X-Gitlab-Token", "wrong")
+	req.Header.Set("X-Gitlab-Event", "push")
+
+	_, err := r.Hook(req, "shh")
+	assert.Equal(t, errBadSignature, err)
+}
+
+func TestBitbucketHook(t *testing.T) {
+	r := &bitbucketRemote{}
+	secret := "shh"
+	body := []byte(`{"pull_request":{"base":{"ref":"main"}}}`)
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	sig := "sha1=" + hex.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(string(body)))
+	req.Header.Set("X-Hub-Signature", sig)
+	req.Header.Set("X-Event-Key", "pullrequest:created")
+
+	branch, err := r.Hook(req, secret)
+	assert.Nil(t, err)
+	assert.Equal(t, "main", branch)
+}
+
+func TestExtractBranch(t *testing.T) {
+	branch, err := extractBranch("push", []byte(`{"ref":"refs/heads/feature/x"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "feature/x", branch)
+
+	branch, err = extractBranch("pull_request", []byte(`{"pull_request":{"base":{"ref":"main"}}}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "main", branch)
+
+	_, err = extractBranch("push", []byte(`not json`))
+	assert.NotNil(t, err)
+}
+
+func TestVerifySHA1AndSHA256(t *testing.T) {
+	body := []byte("payload")
+	secret := "secret"
+
+	mac1 := hmac.New(sha1.New, []byte(secret))
+	mac1.Write(body)
+	sig1 := hex.EncodeToString(mac1.Sum(nil))
+	assert.True(t, verifySHA1(secret, sig1, body))
+	assert.False(t, verifySHA1("wrong", sig1, body))
+
+	mac256 := hmac.New(sha256.New, []byte(secret))
+	mac256.Write(body)
+	sig256 := hex.EncodeToString(mac256.Sum(nil))
+	assert.True(t, verifySHA256(secret, sig256, body))
+	assert.False(t, verifySHA256("wrong", sig256, body))
+}
+
+func TestVerifyHMAC_MalformedSignature(t *testing.T) {
+	body := []byte("payload")
+	secret := "secret"
+
+	assert.False(t, verifySHA1(secret, "not-hex", body))
+	assert.False(t, verifySHA1(secret, "abc", body)) // odd-length
+	assert.False(t, verifySHA256(secret, "not-hex", body))
+}
+
+func TestNew_UnknownProvider(t *testing.T) {
+	_, err := New(Provider("unknown"))
+	assert.Equal(t, errUnknownProvider, err)
+}
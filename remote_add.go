@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/ubclaunchpad/inertia/cfg"
+	"github.com/ubclaunchpad/inertia/common"
+	"github.com/ubclaunchpad/inertia/remote"
+)
+
+var cmdRemote = &cobra.Command{
+	Use:   "remote",
+	Short: "Manage this project's remotes",
+}
+
+var cmdRemoteAdd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Add a new remote",
+	Long: `Adds a new remote to this project's inertia.toml.
+
+With no flags, walks through an interactive setup. Passing --user, --ip,
+--pem, --secret, --branch, or --provider skips the walkthrough and uses
+the given values instead; --from-file reads a YAML file shaped like a
+remote instead of either.
+
+--provider only validates that the given Git host is one Inertia knows
+about. "remote add" does not activate a webhook on any provider, GitHub
+included, so the push/PR/tag webhook is configured by hand.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		path, _ := cmd.Flags().GetString("config")
+		config, err := loadOrInitConfig(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		port, _ := cmd.Flags().GetString("port")
+		sshPort, _ := cmd.Flags().GetString("ssh-port")
+		fromFile, _ := cmd.Flags().GetString("from-file")
+
+		flags := remoteFlags{}
+		flags.PEM, _ = cmd.Flags().GetString("pem")
+		flags.User, _ = cmd.Flags().GetString("user")
+		flags.IP, _ = cmd.Flags().GetString("ip")
+		flags.Secret, _ = cmd.Flags().GetString("secret")
+		flags.Branch, _ = cmd.Flags().GetString("branch")
+		flags.Provider, _ = cmd.Flags().GetString("provider")
+
+		currBranch := currentBranch()
+
+		switch {
+		case fromFile != "":
+			err = addRemoteFromFile(config, fromFile)
+		case flags.IsSet():
+			err = addRemoteFromFlags(config, name, port, sshPort, currBranch, flags)
+		default:
+			err = addRemoteWalkthrough(os.Stdin, config, name, port, sshPort, currBranch, flags.Provider)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err = config.Write(path); err != nil {
+			log.Fatal(err)
+		}
+		log.Infof("remote '%s' added to %s", name, path)
+
+		if remoteVPS, found := config.GetRemote(name); found && remoteVPS.Provider != string(remote.GitHub) {
+			log.Warn("webhook activation is not yet implemented for non-GitHub providers - " +
+				"you will need to configure the push/PR/tag webhook on " + remoteVPS.Provider +
+				" yourself before deployments can be triggered automatically")
+		}
+	},
+}
+
+func init() {
+	cmdRemote.AddCommand(cmdRemoteAdd)
+
+	cmdRemoteAdd.Flags().String("port", "4303", "daemon port")
+	cmdRemoteAdd.Flags().String("ssh-port", "22", "SSH port")
+	cmdRemoteAdd.Flags().String("pem", "", "path to PEM file for SSH access")
+	cmdRemoteAdd.Flags().String("user", "", "user on the remote VPS")
+	cmdRemoteAdd.Flags().String("ip", "", "IP address of the remote VPS")
+	cmdRemoteAdd.Flags().String("secret", "", "webhook secret (generated if left blank)")
+	cmdRemoteAdd.Flags().String("branch", "", "project branch to deploy (defaults to the current branch)")
+	cmdRemoteAdd.Flags().String("provider", "", "remote's Git provider - github, gitlab, bitbucket, gitea, or coding (defaults to github)")
+	cmdRemoteAdd.Flags().String("from-file", "", "path to a YAML file shaped like a remote, used instead of the above flags")
+}
+
+// loadOrInitConfig reads the project's inertia.toml from path, or
+// returns an empty Config if it doesn't exist yet
+func loadOrInitConfig(path string) (*cfg.Config, error) {
+	config, err := cfg.Load(path)
+	if os.IsNotExist(err) {
+		return &cfg.Config{Remotes: make(map[string]*cfg.RemoteVPS)}, nil
+	}
+	return config, err
+}
+
+// currentBranch returns the checked-out branch of the repository rooted
+// at the working directory, or "" if it can't be determined
+func currentBranch() string {
+	repo, err := common.GetLocalRepo()
+	if err != nil {
+		return ""
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return ""
+	}
+	return head.Name().Short()
+}
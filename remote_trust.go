@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ubclaunchpad/inertia/cfg"
+	"github.com/ubclaunchpad/inertia/client"
+)
+
+// errCertNotTrusted is returned when a user declines to trust a remote's
+// certificate fingerprint during "inertia remote add"
+var errCertNotTrusted = errors.New("certificate was not trusted")
+
+// pinCertificate fetches the daemon's certificate at remote's address and
+// pins it to remote.Daemon.TrustedCert, trust-on-first-use style.
+//
+// When interactive, the fingerprint is printed and the user must type "y"
+// on in to confirm it before it's pinned - declining aborts "remote add"
+// entirely, since every request to an unpinned remote would otherwise
+// fail closed (see buildHTTPSClient). Non-interactive callers (flags or
+// --from-file, used for CI bootstrap) have nobody to prompt, so the
+// fingerprint is printed for the operator's logs and trusted immediately;
+// `inertia remote trust --rotate` can always re-pin later.
+func pinCertificate(in io.Reader, remote *cfg.RemoteVPS, interactive bool) error {
+	fingerprint, pemBytes, err := client.FetchCertFingerprint(remote.GetIPAndPort())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Certificate fingerprint for %s: %s\n", remote.Name, client.FormatFingerprint(fingerprint))
+
+	if interactive {
+		fmt.Print("Trust this certificate? [y/N]: ")
+		response, _ := bufio.NewReader(in).ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(response)) != "y" {
+			return errCertNotTrusted
+		}
+	}
+
+	remote.Daemon.TrustedCert = pemBytes
+	return nil
+}